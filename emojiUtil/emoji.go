@@ -0,0 +1,144 @@
+// Package emojiUtil 提供几种 emoji 与纯文本之间互相转换的编解码器，
+// 用于在只支持 ASCII/有限字符集的存储或传输通道（短信网关、老旧数据库字段等）里保留 emoji 信息。
+package emojiUtil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Codec 是一种 emoji 编解码方案的统一接口，Encode 把原文里的 emoji 转成某种文本转义形式，
+// Decode 做相反的事情。内置了 UnicodeCodec、ShortcodeCodec、HTMLCodec、UTF8BytesCodec 四种，
+// 也可以用 RegisterCodec 注册自定义实现，或者用 Compose 把多个 Codec 串起来使用。
+type Codec interface {
+	Encode(s string) string
+	Decode(s string) string
+}
+
+// emojiRange 是一段连续的 emoji 码位区间。
+type emojiRange struct {
+	lo, hi rune
+}
+
+// emojiRanges 覆盖常见的 emoji 相关 Unicode 区块，不追求覆盖每一个码位，
+// 但足以识别绝大多数表情、符号和旗帜字符，包括基本多文种平面（BMP）之外的码位。
+// 这张表和 emojiShortcodeTable 是分开维护的，加新的短代码条目时要同步确认这里也覆盖了它的码位，
+// 否则会出现 EncodeEmojiShortcode 认识但 EncodeEmojiUnicode/HTML/UTF8Bytes 不认识同一个字符的情况。
+var emojiRanges = []emojiRange{
+	{0x2600, 0x26FF},   // Miscellaneous Symbols
+	{0x2700, 0x27BF},   // Dingbats
+	{0x2B00, 0x2BFF},   // Miscellaneous Symbols and Arrows（包含 ⭐ U+2B50）
+	{0x1F1E6, 0x1F1FF}, // Regional Indicator Symbols（国旗）
+	{0x1F300, 0x1F5FF}, // Miscellaneous Symbols and Pictographs
+	{0x1F600, 0x1F64F}, // Emoticons
+	{0x1F680, 0x1F6FF}, // Transport and Map Symbols
+	{0x1F700, 0x1F77F}, // Alchemical Symbols
+	{0x1F780, 0x1F7FF}, // Geometric Shapes Extended
+	{0x1F800, 0x1F8FF}, // Supplemental Arrows-C
+	{0x1F900, 0x1F9FF}, // Supplemental Symbols and Pictographs
+	{0x1FA00, 0x1FA6F}, // Chess Symbols
+	{0x1FA70, 0x1FAFF}, // Symbols and Pictographs Extended-A
+}
+
+// isEmojiRune 判断 r 是否落在 emojiRanges 覆盖的区块里。
+// 像 ZERO WIDTH JOINER（U+200D）这样单独使用没有意义的连接符不会被当成 emoji，
+// 编码时原样保留，这样像 "👨‍👩‍👧" 这种由 ZWJ 连接的多码位序列在编码后仍然靠 ZWJ 粘在一起，
+// 解码回去后也能还原出同一个序列，而不会被拆散或丢字符。
+func isEmojiRune(r rune) bool {
+	for _, rg := range emojiRanges {
+		if r >= rg.lo && r <= rg.hi {
+			return true
+		}
+	}
+	return false
+}
+
+// unicodeTokenPattern 匹配 "[\uXXXX]" 形式的转义标记，十六进制部分大小写不敏感，
+// 长度 2~8 位以同时兼容 BMP 内和 BMP 外（如 0x1F602）的码位。
+var unicodeTokenPattern = regexp.MustCompile(`(?i)\[\\u([0-9a-f]{2,8})\]`)
+
+// EncodeEmojiUnicode 把字符串里的 emoji 逐个替换成本模块约定的 "[\uXXXX]" 转义形式，
+// 非 emoji 字符原样保留。由于是按 rune 遍历，BMP 之外的码位（如 😂 = U+1F602）
+// 和 ZWJ 连接的多码位序列都能被正确处理，不会像基于 UTF-16 代理对拼接的实现那样出错。
+func EncodeEmojiUnicode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if isEmojiRune(r) {
+			fmt.Fprintf(&b, "[\\u%X]", r)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// DecodeEmojiUnicode 把 EncodeEmojiUnicode 产生的 "[\uXXXX]" 标记还原成对应的 emoji 字符，
+// 其他内容原样保留。
+func DecodeEmojiUnicode(s string) string {
+	return unicodeTokenPattern.ReplaceAllStringFunc(s, func(tok string) string {
+		m := unicodeTokenPattern.FindStringSubmatch(tok)
+		code, err := strconv.ParseInt(m[1], 16, 32)
+		if err != nil {
+			return tok
+		}
+		return string(rune(code))
+	})
+}
+
+type unicodeCodec struct{}
+
+func (unicodeCodec) Encode(s string) string { return EncodeEmojiUnicode(s) }
+func (unicodeCodec) Decode(s string) string { return DecodeEmojiUnicode(s) }
+
+// 内置的四种 Codec，可以直接使用，也可以通过 CodecByName 按名字取出。
+var (
+	UnicodeCodec   Codec = unicodeCodec{}
+	ShortcodeCodec Codec = shortcodeCodec{}
+	HTMLCodec      Codec = htmlCodec{}
+	UTF8BytesCodec Codec = utf8BytesCodec{}
+)
+
+var registeredCodecs = map[string]Codec{
+	"unicode":   UnicodeCodec,
+	"shortcode": ShortcodeCodec,
+	"html":      HTMLCodec,
+	"utf8bytes": UTF8BytesCodec,
+}
+
+// RegisterCodec 注册一个自定义 Codec，之后可以用 CodecByName(name) 取出来使用。
+// 传入已存在的名字会覆盖原来的实现。
+func RegisterCodec(name string, codec Codec) {
+	registeredCodecs[name] = codec
+}
+
+// CodecByName 按名字取出一个已注册的 Codec，内置 "unicode"、"shortcode"、"html"、"utf8bytes"。
+func CodecByName(name string) (Codec, bool) {
+	codec, ok := registeredCodecs[name]
+	return codec, ok
+}
+
+// Compose 把多个 Codec 串成一个新的 Codec：Encode 按传入顺序依次应用，
+// Decode 按相反的顺序应用，方便需要叠加多种转义规则的场景。
+func Compose(codecs ...Codec) Codec {
+	return composedCodec{codecs: codecs}
+}
+
+type composedCodec struct {
+	codecs []Codec
+}
+
+func (c composedCodec) Encode(s string) string {
+	for _, codec := range c.codecs {
+		s = codec.Encode(s)
+	}
+	return s
+}
+
+func (c composedCodec) Decode(s string) string {
+	for i := len(c.codecs) - 1; i >= 0; i-- {
+		s = c.codecs[i].Decode(s)
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+package emojiUtil
+
+import "testing"
+
+func TestEncodeDecodeEmojiShortcode(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Hello 😂", "Hello :joy:"},
+		{"👍👎", ":thumbsup::thumbsdown:"},
+		{"No emoji", "No emoji"},
+	}
+
+	for _, tc := range testCases {
+		encoded := EncodeEmojiShortcode(tc.input)
+		if encoded != tc.expected {
+			t.Errorf("EncodeEmojiShortcode(%q) = %q; want %q", tc.input, encoded, tc.expected)
+		}
+
+		decoded := DecodeEmojiShortcode(encoded)
+		if decoded != tc.input {
+			t.Errorf("DecodeEmojiShortcode(%q) = %q; want %q", encoded, decoded, tc.input)
+		}
+	}
+}
+
+func TestEncodeDecodeEmojiHTML(t *testing.T) {
+	input := "Great job 😂"
+	encoded := EncodeEmojiHTML(input)
+	if encoded != "Great job &#128514;" {
+		t.Errorf("EncodeEmojiHTML(%q) = %q", input, encoded)
+	}
+
+	decoded := DecodeEmojiHTML(encoded)
+	if decoded != input {
+		t.Errorf("DecodeEmojiHTML(%q) = %q; want %q", encoded, decoded, input)
+	}
+}
+
+func TestEncodeDecodeEmojiUTF8Bytes(t *testing.T) {
+	input := "Nice 😂"
+	encoded := EncodeEmojiUTF8Bytes(input)
+	if encoded != `Nice \xF0\x9F\x98\x82` {
+		t.Errorf("EncodeEmojiUTF8Bytes(%q) = %q", input, encoded)
+	}
+
+	decoded := DecodeEmojiUTF8Bytes(encoded)
+	if decoded != input {
+		t.Errorf("DecodeEmojiUTF8Bytes(%q) = %q; want %q", encoded, decoded, input)
+	}
+}
+
+// TestEncodeDecodeEmojiUTF8BytesAdjacent 验证相邻的多个 emoji（不管编码后字节长度是否一样）
+// 连写在一起时也能整段解码回来，而不会因为字节边界没对齐成固定宽度而解码失败或解出半个字符。
+func TestEncodeDecodeEmojiUTF8BytesAdjacent(t *testing.T) {
+	testCases := []string{
+		"⭐⭐",
+		"⭐😂",
+	}
+
+	for _, input := range testCases {
+		encoded := EncodeEmojiUTF8Bytes(input)
+		decoded := DecodeEmojiUTF8Bytes(encoded)
+		if decoded != input {
+			t.Errorf("DecodeEmojiUTF8Bytes(EncodeEmojiUTF8Bytes(%q)) = %q; want %q (encoded: %q)", input, decoded, input, encoded)
+		}
+	}
+}
+
+// TestZWJSequenceRoundTrip 验证由 ZERO WIDTH JOINER 连接的多码位表情（如家庭表情）
+// 在每一种 codec 下都能完整地编码再解码回原始字符串，而不会丢字符或被拆散。
+func TestZWJSequenceRoundTrip(t *testing.T) {
+	input := "👨‍👩‍👧 family"
+
+	codecs := map[string]Codec{
+		"unicode": UnicodeCodec,
+		"html":      HTMLCodec,
+		"utf8bytes": UTF8BytesCodec,
+	}
+	for name, codec := range codecs {
+		encoded := codec.Encode(input)
+		decoded := codec.Decode(encoded)
+		if decoded != input {
+			t.Errorf("%s codec round-trip = %q; want %q (encoded: %q)", name, decoded, input, encoded)
+		}
+	}
+
+	shortcodeEncoded := EncodeEmojiShortcode(input)
+	if shortcodeEncoded != ":family_man_woman_girl: family" {
+		t.Errorf("EncodeEmojiShortcode(%q) = %q", input, shortcodeEncoded)
+	}
+	if decoded := DecodeEmojiShortcode(shortcodeEncoded); decoded != input {
+		t.Errorf("DecodeEmojiShortcode(%q) = %q; want %q", shortcodeEncoded, decoded, input)
+	}
+}
+
+// TestEmojiRangesCoverShortcodeTable 防止 emojiRanges 和 emojiShortcodeTable 出现分歧：
+// 凡是在短代码表里登记过的单码位 emoji，EncodeEmojiUnicode 也必须能识别它。
+func TestEmojiRangesCoverShortcodeTable(t *testing.T) {
+	for _, entry := range emojiShortcodeTable {
+		runes := []rune(entry.Emoji)
+		if len(runes) != 1 {
+			continue // 多码位的 ZWJ 序列由各个组成码位单独覆盖，这里只检查单码位条目
+		}
+		if !isEmojiRune(runes[0]) {
+			t.Errorf("emojiRanges does not cover %q (:%s:, U+%X)", entry.Emoji, entry.Name, runes[0])
+		}
+	}
+}
+
+func TestCodecByNameAndCompose(t *testing.T) {
+	codec, ok := CodecByName("shortcode")
+	if !ok || codec != ShortcodeCodec {
+		t.Fatalf("CodecByName(%q) = %v, %v; want ShortcodeCodec, true", "shortcode", codec, ok)
+	}
+
+	composed := Compose(ShortcodeCodec, HTMLCodec)
+	input := "😂"
+	encoded := composed.Encode(input)
+	decoded := composed.Decode(encoded)
+	if decoded != input {
+		t.Errorf("composed codec round-trip = %q; want %q (encoded: %q)", decoded, input, encoded)
+	}
+}
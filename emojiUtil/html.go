@@ -0,0 +1,42 @@
+package emojiUtil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// htmlEntityPattern 匹配十进制数字 HTML 实体，例如 "&#128514;"。
+var htmlEntityPattern = regexp.MustCompile(`&#(\d+);`)
+
+// EncodeEmojiHTML 把字符串里的 emoji 逐个替换成十进制数字 HTML 实体（如 "😂" -> "&#128514;"），
+// 非 emoji 字符原样保留。
+func EncodeEmojiHTML(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if isEmojiRune(r) {
+			fmt.Fprintf(&b, "&#%d;", r)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// DecodeEmojiHTML 把十进制数字 HTML 实体还原成对应的 emoji 字符，其他内容原样保留。
+func DecodeEmojiHTML(s string) string {
+	return htmlEntityPattern.ReplaceAllStringFunc(s, func(tok string) string {
+		m := htmlEntityPattern.FindStringSubmatch(tok)
+		code, err := strconv.Atoi(m[1])
+		if err != nil {
+			return tok
+		}
+		return string(rune(code))
+	})
+}
+
+type htmlCodec struct{}
+
+func (htmlCodec) Encode(s string) string { return EncodeEmojiHTML(s) }
+func (htmlCodec) Decode(s string) string { return DecodeEmojiHTML(s) }
@@ -0,0 +1,88 @@
+package emojiUtil
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// emojiShortcodeEntry 关联一个 emoji（可能由多个码位组成，比如带 ZWJ 的家庭表情）
+// 和它对应的 Slack/GitHub 风格短代码名字（不含冒号）。
+type emojiShortcodeEntry struct {
+	Emoji string
+	Name  string
+}
+
+// emojiShortcodeTable 是从 Unicode CLDR 标注里挑出的常用子集，不是完整表，
+// 但覆盖了聊天场景里最常出现的表情，包括需要多个码位拼接的 ZWJ 序列。
+var emojiShortcodeTable = []emojiShortcodeEntry{
+	{"😂", "joy"},
+	{"😀", "grinning"},
+	{"😉", "wink"},
+	{"😊", "blush"},
+	{"😍", "heart_eyes"},
+	{"😎", "sunglasses"},
+	{"😭", "sob"},
+	{"😡", "rage"},
+	{"🙏", "pray"},
+	{"👍", "thumbsup"},
+	{"👎", "thumbsdown"},
+	{"👏", "clap"},
+	{"🙌", "raised_hands"},
+	{"🔥", "fire"},
+	{"🎉", "tada"},
+	{"💯", "100"},
+	{"❤️", "heart"},
+	{"💔", "broken_heart"},
+	{"🚀", "rocket"},
+	{"⭐", "star"},
+	{"👨‍👩‍👧", "family_man_woman_girl"},
+	{"👩‍❤️‍👨", "couple_with_heart_woman_man"},
+}
+
+var (
+	emojiToShortcode map[string]string
+	shortcodeToEmoji map[string]string
+	shortcodePattern = regexp.MustCompile(`:[a-zA-Z0-9_+-]+:`)
+)
+
+func init() {
+	emojiToShortcode = make(map[string]string, len(emojiShortcodeTable))
+	shortcodeToEmoji = make(map[string]string, len(emojiShortcodeTable))
+	for _, entry := range emojiShortcodeTable {
+		emojiToShortcode[entry.Emoji] = entry.Name
+		shortcodeToEmoji[entry.Name] = entry.Emoji
+	}
+
+	// 按 emoji 的字节长度从长到短排序，这样编码时会先匹配多码位的 ZWJ 序列，
+	// 不会被其中某一个单码位表情提前、错误地替换掉。
+	sort.Slice(emojiShortcodeTable, func(i, j int) bool {
+		return len(emojiShortcodeTable[i].Emoji) > len(emojiShortcodeTable[j].Emoji)
+	})
+}
+
+// EncodeEmojiShortcode 把字符串里认识的 emoji 替换成 ":name:" 形式的短代码，
+// 例如 "😂" 变成 ":joy:"。不在表里的 emoji 保持原样。
+func EncodeEmojiShortcode(s string) string {
+	for _, entry := range emojiShortcodeTable {
+		s = strings.ReplaceAll(s, entry.Emoji, ":"+entry.Name+":")
+	}
+	return s
+}
+
+// DecodeEmojiShortcode 把 ":name:" 形式的短代码还原成对应的 emoji，
+// 不认识的短代码原样保留。
+func DecodeEmojiShortcode(s string) string {
+	return shortcodePattern.ReplaceAllStringFunc(s, func(tok string) string {
+		name := strings.Trim(tok, ":")
+		if emoji, ok := shortcodeToEmoji[name]; ok {
+			return emoji
+		}
+		return tok
+	})
+}
+
+type shortcodeCodec struct{}
+
+func (shortcodeCodec) Encode(s string) string { return EncodeEmojiShortcode(s) }
+func (shortcodeCodec) Decode(s string) string { return DecodeEmojiShortcode(s) }
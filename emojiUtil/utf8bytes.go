@@ -0,0 +1,62 @@
+package emojiUtil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// utf8BytesPattern 匹配连续写在一起的任意长度 "\xHH" 字节转义串。
+// 不限定成 2~4 组是因为相邻多个 emoji（甚至 ZWJ 序列）编码后字节会连成一整段，
+// 长度不一定是 4 的倍数；整段一起解码成 []byte 再校验 UTF-8 合法性，
+// 可以正确还原出这段里包含的全部 rune，而不会按固定宽度切错边界。
+var utf8BytesPattern = regexp.MustCompile(`(?:\\x[0-9A-Fa-f]{2})+`)
+
+// EncodeEmojiUTF8Bytes 把字符串里的 emoji 替换成它 UTF-8 编码的逐字节转义形式，
+// 例如 "😂" -> "\xF0\x9F\x98\x82"，这是部分数据库、日志系统存储非 ASCII 字节时采用的形式。
+// 非 emoji 字符原样保留。
+func EncodeEmojiUTF8Bytes(s string) string {
+	var b strings.Builder
+	buf := make([]byte, utf8.UTFMax)
+	for _, r := range s {
+		if isEmojiRune(r) {
+			n := utf8.EncodeRune(buf, r)
+			for _, bb := range buf[:n] {
+				fmt.Fprintf(&b, "\\x%02X", bb)
+			}
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// DecodeEmojiUTF8Bytes 把 EncodeEmojiUTF8Bytes 产生的逐字节转义还原成对应的 emoji 字符，
+// 非法或无法组成完整 UTF-8 序列的转义原样保留。
+func DecodeEmojiUTF8Bytes(s string) string {
+	return utf8BytesPattern.ReplaceAllStringFunc(s, func(tok string) string {
+		hexParts := strings.Split(tok, `\x`)
+		buf := make([]byte, 0, len(hexParts))
+		for _, h := range hexParts {
+			if h == "" {
+				continue
+			}
+			v, err := strconv.ParseUint(h, 16, 8)
+			if err != nil {
+				return tok
+			}
+			buf = append(buf, byte(v))
+		}
+		if !utf8.Valid(buf) {
+			return tok
+		}
+		return string(buf)
+	})
+}
+
+type utf8BytesCodec struct{}
+
+func (utf8BytesCodec) Encode(s string) string { return EncodeEmojiUTF8Bytes(s) }
+func (utf8BytesCodec) Decode(s string) string { return DecodeEmojiUTF8Bytes(s) }
@@ -0,0 +1,102 @@
+package jsonUtil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Token 是流式解析过程中的一个词法单元：json.Delim（'{'、'}'、'['、']'）、
+// bool、float64、json.Number、string 或 nil，具体种类通过类型断言判断。
+type Token = json.Token
+
+// Decoder 在 io.Reader 上做增量式 JSON 解析，适合处理体量较大、不适合一次性
+// 读入内存再 Unmarshal 的 JSON（例如一个很长的记录数组），调用方可以边读边处理，
+// 不必等整份文档都到达。它是对 encoding/json.Decoder 的一层轻量封装，
+// Token/More/Decode 的语义都和标准库保持一致。
+type Decoder struct {
+	dec *json.Decoder
+}
+
+// NewDecoder 基于 r 创建一个 Decoder。
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+// Token 返回输入流中的下一个词法单元。
+func (d *Decoder) Token() (Token, error) {
+	return d.dec.Token()
+}
+
+// More 报告当前正在读取的数组或对象中是否还有下一个元素，
+// 通常和 Token 搭配在循环里使用，逐个处理数组中的记录而不必先读完整个数组。
+func (d *Decoder) More() bool {
+	return d.dec.More()
+}
+
+// Decode 把输入流中的下一个 JSON 值解码进 v，语义等同于 encoding/json.Decoder.Decode。
+func (d *Decoder) Decode(v interface{}) error {
+	return d.dec.Decode(v)
+}
+
+// Walk 顺序读取 r 中的 JSON 文档，为文档里每一个叶子节点（非对象/数组的标量值）
+// 回调一次 cb，path 是 JSONPath 风格的点分路径（数组下标以十进制整数表示），
+// 例如顶层数组第二条记录的 name 字段路径是 "1.name"。
+// 内存占用只与当前嵌套深度有关，而不是整份文档的大小，适合处理体积较大的 JSON 日志。
+func Walk(r io.Reader, cb func(path string, value interface{}) error) error {
+	dec := json.NewDecoder(r)
+	return walkValue(dec, "", cb)
+}
+
+// walkValue 读取下一个 token 并分派给 walkToken，对应一个完整 JSON 值的起点。
+func walkValue(dec *json.Decoder, path string, cb func(path string, value interface{}) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	return walkToken(dec, path, tok, cb)
+}
+
+// walkToken 根据 tok 的类型决定是递归展开对象/数组，还是把标量值交给 cb。
+func walkToken(dec *json.Decoder, path string, tok json.Token, cb func(path string, value interface{}) error) error {
+	delim, isDelim := tok.(json.Delim)
+	if !isDelim {
+		return cb(path, tok)
+	}
+
+	switch delim {
+	case '{':
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := keyTok.(string)
+			if err := walkValue(dec, joinPath(path, key), cb); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token() // 消费结尾的 '}'
+		return err
+	case '[':
+		idx := 0
+		for dec.More() {
+			if err := walkValue(dec, joinPath(path, strconv.Itoa(idx)), cb); err != nil {
+				return err
+			}
+			idx++
+		}
+		_, err := dec.Token() // 消费结尾的 ']'
+		return err
+	default:
+		return fmt.Errorf("jsonUtil: unexpected delimiter %v", delim)
+	}
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
@@ -0,0 +1,108 @@
+package jsonUtil
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDecoderTokenMoreDecode(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`[{"name":"Alice"},{"name":"Bob"}]`))
+
+	tok, err := dec.Token()
+	if err != nil {
+		t.Fatalf("Token error: %v", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		t.Fatalf("first token = %v; want '['", tok)
+	}
+
+	var names []string
+	for dec.More() {
+		var rec struct {
+			Name string `json:"name"`
+		}
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatalf("Decode error: %v", err)
+		}
+		names = append(names, rec.Name)
+	}
+
+	want := []string{"Alice", "Bob"}
+	if len(names) != len(want) {
+		t.Fatalf("got %v; want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("names[%d] = %q; want %q", i, names[i], want[i])
+		}
+	}
+}
+
+func TestWalkNestedObjectsAndArrays(t *testing.T) {
+	doc := `{"users":[{"name":"Alice","tags":["a","b"]},{"name":"Bob","tags":[]}],"count":2}`
+
+	var paths []string
+	values := map[string]interface{}{}
+	err := Walk(strings.NewReader(doc), func(path string, value interface{}) error {
+		paths = append(paths, path)
+		values[path] = value
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk error: %v", err)
+	}
+
+	wantPaths := []string{
+		"users.0.name",
+		"users.0.tags.0",
+		"users.0.tags.1",
+		"users.1.name",
+		"count",
+	}
+	if len(paths) != len(wantPaths) {
+		t.Fatalf("got paths %v; want %v", paths, wantPaths)
+	}
+	for i := range wantPaths {
+		if paths[i] != wantPaths[i] {
+			t.Errorf("paths[%d] = %q; want %q", i, paths[i], wantPaths[i])
+		}
+	}
+
+	if values["users.0.name"] != "Alice" {
+		t.Errorf("users.0.name = %v; want Alice", values["users.0.name"])
+	}
+	if values["count"] != float64(2) {
+		t.Errorf("count = %v; want 2", values["count"])
+	}
+}
+
+// TestWalkEmptyContainers 确认空对象/空数组不会产生任何叶子回调，也不会让 Walk 卡住或出错，
+// 比如上一个用例里 Bob 的空 tags 数组：users.1.tags 不应该出现在任何路径里。
+func TestWalkEmptyContainers(t *testing.T) {
+	doc := `{"a":{},"b":[],"c":1}`
+
+	var paths []string
+	err := Walk(strings.NewReader(doc), func(path string, value interface{}) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk error: %v", err)
+	}
+
+	if len(paths) != 1 || paths[0] != "c" {
+		t.Errorf("paths = %v; want [\"c\"] (empty object/array should not emit leaves)", paths)
+	}
+}
+
+func TestJsonToStructFromReader(t *testing.T) {
+	var u optionsTestUser
+	err := JsonToStructFromReader(strings.NewReader(`{"name":"Carol","active":true}`), &u)
+	if err != nil {
+		t.Fatalf("JsonToStructFromReader error: %v", err)
+	}
+	if u.Name != "Carol" || !u.Active {
+		t.Errorf("unexpected result: %+v", u)
+	}
+}
@@ -0,0 +1,508 @@
+package jsonUtil
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Result 表示一次 Get/GetMany 查询命中的 JSON 片段。
+// 它只持有原始的 JSON 文本（raw），真正的类型转换在调用 String/Int/Float/Bool 等方法时才发生，
+// 因此即便只取一两个字段，也不需要把整份 JSON Unmarshal 成 map 或结构体。
+type Result struct {
+	raw    string
+	exists bool
+}
+
+// Exists 返回该路径是否在 JSON 中命中。
+func (r Result) Exists() bool {
+	return r.exists
+}
+
+// Raw 返回命中片段未经任何转换的原始 JSON 文本。
+func (r Result) Raw() string {
+	return r.raw
+}
+
+// String 将结果转换为字符串。JSON 字符串会去掉引号并处理转义，
+// 其他类型（数字、布尔等）按原始文本返回。
+func (r Result) String() string {
+	if !r.exists {
+		return ""
+	}
+	raw := strings.TrimSpace(r.raw)
+	if len(raw) >= 2 && raw[0] == '"' {
+		return unquoteJSONString(raw)
+	}
+	return raw
+}
+
+// Int 将结果转换为 int64，无法解析时返回 0。
+// 优先按整数直接解析 raw 文本，避免像 9007199254740993 这种超出 float64 精确表示范围
+// （2^53）的大整数，在经过 float64 中转时丢失精度；带引号的字符串会先去掉引号再按同样规则解析。
+// 只有当内容不是整数字面量（比如带小数点）时才退回 Float() 再做截断。
+func (r Result) Int() int64 {
+	if !r.exists {
+		return 0
+	}
+	raw := strings.TrimSpace(r.raw)
+	if len(raw) >= 2 && raw[0] == '"' {
+		raw = unquoteJSONString(raw)
+	}
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i
+	}
+	return int64(r.Float())
+}
+
+// Float 将结果转换为 float64，无法解析时返回 0。
+func (r Result) Float() float64 {
+	if !r.exists {
+		return 0
+	}
+	raw := strings.TrimSpace(r.raw)
+	if len(raw) >= 2 && raw[0] == '"' {
+		raw = unquoteJSONString(raw)
+	}
+	f, _ := strconv.ParseFloat(raw, 64)
+	return f
+}
+
+// Bool 将结果转换为 bool，只有字面量 true 被视为真。
+func (r Result) Bool() bool {
+	return strings.TrimSpace(r.raw) == "true"
+}
+
+// Array 将结果展开为子 Result 切片。如果命中的不是 JSON 数组，
+// 返回只包含自身的单元素切片，方便调用方统一处理。
+func (r Result) Array() []Result {
+	if !r.exists {
+		return nil
+	}
+	raw := strings.TrimSpace(r.raw)
+	if len(raw) == 0 || raw[0] != '[' {
+		return []Result{r}
+	}
+	var out []Result
+	forEachArrayElem(raw, func(elem string) bool {
+		out = append(out, Result{raw: elem, exists: true})
+		return true
+	})
+	return out
+}
+
+// Map 将结果展开为 key -> Result 的映射。如果命中的不是 JSON 对象，返回空 map。
+func (r Result) Map() map[string]Result {
+	out := map[string]Result{}
+	if !r.exists {
+		return out
+	}
+	raw := strings.TrimSpace(r.raw)
+	if len(raw) == 0 || raw[0] != '{' {
+		return out
+	}
+	forEachObjectPair(raw, func(key, val string) bool {
+		out[key] = Result{raw: val, exists: true}
+		return true
+	})
+	return out
+}
+
+// ForEach 遍历对象或数组的直接子元素。对象遍历时 key 为字段名（以字符串 Result 形式给出），
+// 数组遍历时 key 为索引（以数字 Result 形式给出）。iterator 返回 false 时提前终止遍历。
+func (r Result) ForEach(iterator func(key, value Result) bool) {
+	if !r.exists {
+		return
+	}
+	raw := strings.TrimSpace(r.raw)
+	if raw == "" {
+		return
+	}
+	switch raw[0] {
+	case '{':
+		forEachObjectPair(raw, func(key, val string) bool {
+			return iterator(Result{raw: `"` + key + `"`, exists: true}, Result{raw: val, exists: true})
+		})
+	case '[':
+		i := 0
+		forEachArrayElem(raw, func(elem string) bool {
+			keyRes := Result{raw: strconv.Itoa(i), exists: true}
+			i++
+			return iterator(keyRes, Result{raw: elem, exists: true})
+		})
+	}
+}
+
+// Get 按点分路径从 jsonData 中取出一个值，无需定义结构体。
+//
+// 路径支持：
+//   - 普通字段： "user.address.city"
+//   - 数组下标： "friends.0.name"
+//   - 数组投影： "items.#.price"，对数组中每个元素取 price 字段，结果是一个 JSON 数组
+//   - 简单过滤： "users.#(age>30).name"，取数组中第一个满足条件的元素后继续取 name
+//   - 转义点号：字段名中包含 "." 时写作 "\."，例如 "a\.b.c"
+//
+// 实现上不会对 jsonData 做 json.Unmarshal，而是用一个只扫描一遍原始字节的游标
+// （用计数代替显式栈跟踪 {}/[] 的嵌套、并跳过字符串和转义字符）按需定位路径对应的片段，
+// 字段越少、JSON 越大，相对 JsonToStruct 的性能优势越明显。
+//
+// 如果路径不存在，返回的 Result.Exists() 为 false，这种情况不视为错误；
+// path 指向的容器类型不匹配（比如对对象使用数组下标）同样按路径不存在处理。
+// 只有当 jsonData 为空（或只含空白）时才会返回 error。
+func Get(jsonData string, path string) (Result, error) {
+	trimmed := strings.TrimSpace(jsonData)
+	if trimmed == "" {
+		return Result{}, errors.New("jsonUtil: empty JSON document")
+	}
+
+	raw, ok := get(trimmed, splitPath(path))
+	if !ok {
+		return Result{}, nil
+	}
+	return Result{raw: raw, exists: true}, nil
+}
+
+// GetMany 一次性取出多个路径对应的值，返回的切片与 paths 一一对应。
+func GetMany(jsonData string, paths ...string) ([]Result, error) {
+	results := make([]Result, len(paths))
+	for i, path := range paths {
+		res, err := Get(jsonData, path)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = res
+	}
+	return results, nil
+}
+
+// splitPath 把点分路径切分成片段，"\." 会被还原成字面量 "." 而不触发切分。
+func splitPath(path string) []string {
+	var segs []string
+	var buf strings.Builder
+	escaped := false
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if escaped {
+			buf.WriteByte(c)
+			escaped = false
+			continue
+		}
+		if c == '\\' {
+			escaped = true
+			continue
+		}
+		if c == '.' {
+			segs = append(segs, buf.String())
+			buf.Reset()
+			continue
+		}
+		buf.WriteByte(c)
+	}
+	segs = append(segs, buf.String())
+	return segs
+}
+
+// get 递归地沿着 segs 在 raw 片段中定位下一段，raw 始终是某个 JSON 值的原始文本。
+func get(raw string, segs []string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if len(segs) == 0 {
+		return raw, raw != ""
+	}
+
+	seg := segs[0]
+	rest := segs[1:]
+
+	switch {
+	case seg == "#":
+		if len(raw) == 0 || raw[0] != '[' {
+			return "", false
+		}
+		if len(rest) == 0 {
+			return strconv.Itoa(arrayLen(raw)), true
+		}
+		var parts []string
+		forEachArrayElem(raw, func(elem string) bool {
+			if v, ok := get(elem, rest); ok {
+				parts = append(parts, v)
+			} else {
+				parts = append(parts, "null")
+			}
+			return true
+		})
+		return "[" + strings.Join(parts, ",") + "]", true
+
+	case strings.HasPrefix(seg, "#(") && strings.HasSuffix(seg, ")"):
+		if len(raw) == 0 || raw[0] != '[' {
+			return "", false
+		}
+		expr := seg[2 : len(seg)-1]
+		var found string
+		var matched bool
+		forEachArrayElem(raw, func(elem string) bool {
+			if matchFilter(elem, expr) {
+				found, matched = elem, true
+				return false
+			}
+			return true
+		})
+		if !matched {
+			return "", false
+		}
+		return get(found, rest)
+
+	default:
+		if idx, err := strconv.Atoi(seg); err == nil {
+			if len(raw) == 0 || raw[0] != '[' {
+				return "", false
+			}
+			elem, ok := findIndexInArray(raw, idx)
+			if !ok {
+				return "", false
+			}
+			return get(elem, rest)
+		}
+
+		if len(raw) == 0 || raw[0] != '{' {
+			return "", false
+		}
+		val, ok := findKeyInObject(raw, seg)
+		if !ok {
+			return "", false
+		}
+		return get(val, rest)
+	}
+}
+
+// matchFilter 解析并求值形如 "age>30"、"name==\"Bob\"" 的简单过滤表达式。
+func matchFilter(elem string, expr string) bool {
+	for _, op := range []string{">=", "<=", "!=", "==", ">", "<"} {
+		idx := strings.Index(expr, op)
+		if idx == -1 {
+			continue
+		}
+		key := strings.TrimSpace(expr[:idx])
+		want := strings.TrimSpace(expr[idx+len(op):])
+		raw, ok := get(elem, splitPath(key))
+		if !ok {
+			return false
+		}
+		return compareFilterValue(raw, want, op)
+	}
+	return false
+}
+
+func compareFilterValue(raw, want, op string) bool {
+	wantStr := strings.Trim(want, `"`)
+	rawNum, rawErr := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	wantNum, wantErr := strconv.ParseFloat(wantStr, 64)
+	if rawErr == nil && wantErr == nil {
+		switch op {
+		case ">":
+			return rawNum > wantNum
+		case "<":
+			return rawNum < wantNum
+		case ">=":
+			return rawNum >= wantNum
+		case "<=":
+			return rawNum <= wantNum
+		case "==":
+			return rawNum == wantNum
+		case "!=":
+			return rawNum != wantNum
+		}
+	}
+
+	rawStr := strings.Trim(strings.TrimSpace(raw), `"`)
+	switch op {
+	case "==":
+		return rawStr == wantStr
+	case "!=":
+		return rawStr != wantStr
+	default:
+		return false
+	}
+}
+
+// findKeyInObject 在以 '{' 开头的原始 JSON 文本中查找 key 对应的值片段。
+func findKeyInObject(raw string, key string) (string, bool) {
+	found := ""
+	ok := false
+	forEachObjectPair(raw, func(rawKey, val string) bool {
+		if rawKey == key {
+			found, ok = val, true
+			return false
+		}
+		return true
+	})
+	return found, ok
+}
+
+// forEachObjectPair 遍历对象的每个直接 key/value 对，fn 返回 false 时提前终止。
+func forEachObjectPair(raw string, fn func(key, val string) bool) {
+	i := skipWhitespace(raw, 1)
+	for i < len(raw) && raw[i] != '}' {
+		if raw[i] != '"' {
+			return
+		}
+		keyEnd := skipString(raw, i) - 1
+		rawKey := unquoteJSONString(raw[i : keyEnd+1])
+		i = skipString(raw, i)
+
+		i = skipWhitespace(raw, i)
+		if i >= len(raw) || raw[i] != ':' {
+			return
+		}
+		i = skipWhitespace(raw, i+1)
+
+		valStart := i
+		valEnd := skipValue(raw, i)
+		if !fn(rawKey, raw[valStart:valEnd]) {
+			return
+		}
+
+		i = skipWhitespace(raw, valEnd)
+		if i < len(raw) && raw[i] == ',' {
+			i = skipWhitespace(raw, i+1)
+			continue
+		}
+		return
+	}
+}
+
+// findIndexInArray 在以 '[' 开头的原始 JSON 文本中查找第 idx 个（从 0 开始）元素。
+func findIndexInArray(raw string, idx int) (string, bool) {
+	var found string
+	var ok bool
+	count := 0
+	forEachArrayElem(raw, func(elem string) bool {
+		if count == idx {
+			found, ok = elem, true
+			return false
+		}
+		count++
+		return true
+	})
+	return found, ok
+}
+
+// arrayLen 统计数组的直接子元素个数。
+func arrayLen(raw string) int {
+	n := 0
+	forEachArrayElem(raw, func(string) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// forEachArrayElem 遍历数组的每个直接元素，fn 返回 false 时提前终止。
+func forEachArrayElem(raw string, fn func(elem string) bool) {
+	i := skipWhitespace(raw, 1)
+	for i < len(raw) && raw[i] != ']' {
+		start := i
+		end := skipValue(raw, i)
+		if !fn(strings.TrimSpace(raw[start:end])) {
+			return
+		}
+		i = skipWhitespace(raw, end)
+		if i < len(raw) && raw[i] == ',' {
+			i = skipWhitespace(raw, i+1)
+			continue
+		}
+		return
+	}
+}
+
+// skipWhitespace 跳过 JSON 空白字符，返回第一个非空白字符的下标。
+func skipWhitespace(s string, i int) int {
+	for i < len(s) {
+		switch s[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipValue 跳过从下标 i 开始的一个完整 JSON 值（字符串/对象/数组/数字/true/false/null），
+// 返回该值之后的下标。
+func skipValue(s string, i int) int {
+	i = skipWhitespace(s, i)
+	if i >= len(s) {
+		return i
+	}
+	switch s[i] {
+	case '"':
+		return skipString(s, i)
+	case '{', '[':
+		return skipContainer(s, i)
+	default:
+		j := i
+		for j < len(s) {
+			switch s[j] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return j
+			}
+			j++
+		}
+		return j
+	}
+}
+
+// skipString 跳过从下标 i（s[i] 必须是开头的双引号）开始的一个 JSON 字符串，
+// 正确处理转义字符，返回结尾引号之后的下标。
+func skipString(s string, i int) int {
+	i++ // 跳过开头的引号
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			i += 2
+			continue
+		case '"':
+			return i + 1
+		}
+		i++
+	}
+	return i
+}
+
+// skipContainer 跳过从下标 i（s[i] 是 '{' 或 '['）开始的一个 JSON 对象/数组，
+// 用一个深度计数代替显式栈来匹配同类型的括号，期间原样跳过字符串避免把引号里的括号计入嵌套。
+func skipContainer(s string, i int) int {
+	open := s[i]
+	var closeCh byte
+	if open == '{' {
+		closeCh = '}'
+	} else {
+		closeCh = ']'
+	}
+	depth := 0
+	for i < len(s) {
+		switch s[i] {
+		case '"':
+			i = skipString(s, i)
+			continue
+		case open:
+			depth++
+		case closeCh:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+	return i
+}
+
+// unquoteJSONString 去掉 JSON 字符串两端的引号并处理转义，raw 必须是带引号的完整字符串字面量。
+func unquoteJSONString(raw string) string {
+	var unquoted string
+	if err := json.Unmarshal([]byte(raw), &unquoted); err == nil {
+		return unquoted
+	}
+	return strings.Trim(raw, `"`)
+}
@@ -0,0 +1,120 @@
+package jsonUtil
+
+import "testing"
+
+const getTestDoc = `{
+	"user": {"address": {"city": "Chongqing"}},
+	"a.b": "dotted-key",
+	"friends": [{"name": "Alice"}, {"name": "Bob"}],
+	"items": [{"price": 10}, {"price": 20}, {"price": 30}],
+	"users": [{"name": "Alice", "age": 25}, {"name": "Bob", "age": 35}, {"name": "Carol", "age": 40}]
+}`
+
+func TestGetDottedPath(t *testing.T) {
+	res, err := Get(getTestDoc, "user.address.city")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if !res.Exists() {
+		t.Fatalf("expected path to exist")
+	}
+	if res.String() != "Chongqing" {
+		t.Errorf("Get(user.address.city) = %q; want %q", res.String(), "Chongqing")
+	}
+}
+
+func TestGetArrayIndex(t *testing.T) {
+	res, err := Get(getTestDoc, "friends.0.name")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if res.String() != "Alice" {
+		t.Errorf("Get(friends.0.name) = %q; want %q", res.String(), "Alice")
+	}
+
+	res, err = Get(getTestDoc, "friends.1.name")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if res.String() != "Bob" {
+		t.Errorf("Get(friends.1.name) = %q; want %q", res.String(), "Bob")
+	}
+}
+
+func TestGetArrayProjection(t *testing.T) {
+	res, err := Get(getTestDoc, "items.#.price")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if !res.Exists() {
+		t.Fatalf("expected projection to exist")
+	}
+
+	var prices []int64
+	for _, elem := range res.Array() {
+		prices = append(prices, elem.Int())
+	}
+	want := []int64{10, 20, 30}
+	if len(prices) != len(want) {
+		t.Fatalf("got %v prices; want %v", prices, want)
+	}
+	for i := range want {
+		if prices[i] != want[i] {
+			t.Errorf("prices[%d] = %d; want %d", i, prices[i], want[i])
+		}
+	}
+}
+
+func TestGetFilter(t *testing.T) {
+	res, err := Get(getTestDoc, "users.#(age>30).name")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if res.String() != "Bob" {
+		t.Errorf("Get(users.#(age>30).name) = %q; want %q", res.String(), "Bob")
+	}
+}
+
+func TestGetEscapedDottedKey(t *testing.T) {
+	res, err := Get(getTestDoc, `a\.b`)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if res.String() != "dotted-key" {
+		t.Errorf(`Get(a\.b) = %q; want %q`, res.String(), "dotted-key")
+	}
+}
+
+func TestGetMissingPath(t *testing.T) {
+	res, err := Get(getTestDoc, "user.address.country")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if res.Exists() {
+		t.Errorf("expected missing path to not exist, got raw: %q", res.Raw())
+	}
+}
+
+// TestResultIntPrecision 验证 Result.Int() 直接解析整数字面量，不会像先转 float64 再截断
+// 那样在超出 2^53 的大整数上丢失精度。
+func TestResultIntPrecision(t *testing.T) {
+	doc := `{"id": 9007199254740993}`
+	res, err := Get(doc, "id")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if got := res.Int(); got != 9007199254740993 {
+		t.Errorf("Result.Int() = %d; want %d", got, 9007199254740993)
+	}
+}
+
+func TestResultIntFallsBackToFloatForNonIntegerLiterals(t *testing.T) {
+	doc := `{"n": 3.9}`
+	res, err := Get(doc, "n")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if got := res.Int(); got != 3 {
+		t.Errorf("Result.Int() = %d; want %d", got, 3)
+	}
+}
@@ -4,60 +4,109 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // JsonToStruct 将 JSON 字符串解析为指定的结构体指针
 // 根据结构体的字段类型和标签来自动选择将 JSON 值转换为相应的类型。
 //
-// 支持的字段类型包括 string、int、int8、int16、int32、int64、uint、uint8、uint16、uint32、uint64、bool、float32 和 float64。
+// 支持的字段类型包括 string、int、int8、int16、int32、int64、uint、uint8、uint16、uint32、uint64、bool、float32、float64 和 time.Time。
 //
-// 支持的标签有 "json"、"jsonb" 和 "mapstructure"。
-// - "json" 和 "jsonb" 标签指示解析 JSON 时使用的键名。
-// - "mapstructure" 标签指示字段名的映射关系。
+// 默认情况下依次读取 "json"、"jsonb"、"mapstructure" 标签来确定字段名，
+// 可以通过 WithTagName 改成只认某一个标签。
 //
-// 如果 JSON 中的某些键在结构体中没有对应的字段，则它们将被忽略。
-// 如果 JSON 中的某些键的类型与结构体中的字段类型不匹配，则会引发解析错误。
+// 如果 JSON 中的某些键在结构体中没有对应的字段，则它们默认会被忽略，
+// 传入 WithErrorUnused 可以让这种情况报错，传入 WithMetadata 可以把命中/未命中的 key 记录下来。
+// 如果 JSON 中的某些键的类型与结构体中的字段类型不匹配，则会引发解析错误，
+// 除非传入 WithWeaklyTypedInput 放宽类型匹配，或者传入 WithDecodeHook 自行处理转换。
 //
 // 参数 jsonData 是要解析的 JSON 字符串。
 // 参数 result 是指向要填充 JSON 值的结构体指针。
 //
 // 如果解析成功，则返回 nil。如果解析失败，则返回解析错误。
-func JsonToStruct(jsonData string, result interface{}) error {
+func JsonToStruct(jsonData string, result interface{}, opts ...Option) error {
 	var data map[string]interface{}
 	err := json.Unmarshal([]byte(jsonData), &data)
 	if err != nil {
 		return err
 	}
 
+	return structFromMap(data, result, newDecodeOptions(opts))
+}
+
+// JsonToStructFromReader 与 JsonToStruct 行为一致，只是直接从 io.Reader 读取，
+// 内部基于 Decoder 解码，适合 HTTP 响应体、文件等流式来源，省去调用方先把整个 body 读成字符串的一步。
+func JsonToStructFromReader(r io.Reader, result interface{}, opts ...Option) error {
+	var data map[string]interface{}
+	if err := NewDecoder(r).Decode(&data); err != nil {
+		return err
+	}
+
+	return structFromMap(data, result, newDecodeOptions(opts))
+}
+
+func structFromMap(data map[string]interface{}, result interface{}, opts *decodeOptions) error {
 	resultValue := reflect.ValueOf(result).Elem()
 	resultType := resultValue.Type()
 
+	usedKeys := make(map[string]bool, len(data))
+
 	for i := 0; i < resultType.NumField(); i++ {
 		fieldType := resultType.Field(i)
 		fieldName := fieldType.Name
 		fieldValue := resultValue.FieldByName(fieldName)
 
-		// 从json的tag标签中取出定义字段
-		jsonTag := fieldType.Tag.Get("json")
-		if jsonTag == "" {
-			jsonTag = fieldName
-		} else {
-			if commaIndex := strings.Index(jsonTag, ","); commaIndex != -1 {
-				jsonTag = jsonTag[:commaIndex]
-			}
-		}
+		jsonTag := fieldJSONKey(fieldType, opts)
 
 		value, ok := data[jsonTag]
 		if !ok {
 			continue
 		}
+		usedKeys[jsonTag] = true
+
+		if opts.decodeHook != nil {
+			hooked, err := opts.decodeHook(reflect.TypeOf(value), fieldValue.Type(), value)
+			if err != nil {
+				return err
+			}
+			value = hooked
+			if value != nil {
+				if hv := reflect.ValueOf(value); hv.Type().AssignableTo(fieldValue.Type()) {
+					fieldValue.Set(hv)
+					continue
+				}
+			}
+		}
+
+		if fieldValue.Kind() == reflect.Struct && fieldValue.Type() == timeType {
+			strVal, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("jsonUtil: field %s expects a time string, got %T", fieldName, value)
+			}
+			layout := opts.timeFormat
+			if layout == "" {
+				layout = time.RFC3339
+			}
+			t, err := time.Parse(layout, strVal)
+			if err != nil {
+				return err
+			}
+			fieldValue.Set(reflect.ValueOf(t))
+			continue
+		}
 
 		switch fieldValue.Kind() {
 		case reflect.String:
-			fieldValue.SetString(value.(string))
+			strVal, err := toStringValue(value, opts.weaklyTypedInput)
+			if err != nil {
+				return err
+			}
+			fieldValue.SetString(strVal)
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			val, err := toInt64(value)
 			if err != nil {
@@ -76,10 +125,18 @@ func JsonToStruct(jsonData string, result interface{}) error {
 				return err
 			}
 			fieldValue.SetFloat(val)
+		case reflect.Bool:
+			val, err := toBool(value, opts.weaklyTypedInput)
+			if err != nil {
+				return err
+			}
+			fieldValue.SetBool(val)
 		case reflect.Struct:
 			if subData, ok := value.(map[string]interface{}); ok {
 				subResult := reflect.New(fieldValue.Type())
-				JsonToStruct(convertToJSONString(subData), subResult.Interface())
+				if err := structFromMap(subData, subResult.Interface(), opts); err != nil {
+					return err
+				}
 				fieldValue.Set(subResult.Elem())
 			}
 		case reflect.Slice:
@@ -89,10 +146,12 @@ func JsonToStruct(jsonData string, result interface{}) error {
 					subValue := subData[j]
 					subElem := subResult.Index(j)
 
-					if subElem.Kind() == reflect.Struct {
+					if subElem.Kind() == reflect.Struct && subElem.Type() != timeType {
 						if subDataElem, ok := subValue.(map[string]interface{}); ok {
 							subResultElem := reflect.New(subElem.Type())
-							JsonToStruct(convertToJSONString(subDataElem), subResultElem.Interface())
+							if err := structFromMap(subDataElem, subResultElem.Interface(), opts); err != nil {
+								return err
+							}
 							subElem.Set(subResultElem.Elem())
 						}
 					} else {
@@ -106,12 +165,51 @@ func JsonToStruct(jsonData string, result interface{}) error {
 		}
 	}
 
+	if opts.errorUnused || opts.metadata != nil {
+		var unused []string
+		for key := range data {
+			if !usedKeys[key] {
+				unused = append(unused, key)
+			}
+		}
+		sort.Strings(unused)
+
+		if opts.metadata != nil {
+			matched := make([]string, 0, len(usedKeys))
+			for key := range usedKeys {
+				matched = append(matched, key)
+			}
+			sort.Strings(matched)
+			opts.metadata.Keys = append(opts.metadata.Keys, matched...)
+			opts.metadata.Unused = append(opts.metadata.Unused, unused...)
+		}
+
+		if opts.errorUnused && len(unused) > 0 {
+			return fmt.Errorf("jsonUtil: unused keys in JSON: %s", strings.Join(unused, ", "))
+		}
+	}
+
 	return nil
 }
 
-func convertToJSONString(data map[string]interface{}) string {
-	jsonBytes, _ := json.Marshal(data)
-	return string(jsonBytes)
+// fieldJSONKey 按 opts.tagName 取出字段名；如果调用方没有显式指定 tagName（默认值 "json"），
+// 则依次尝试 "json"、"jsonb"、"mapstructure"，和 JsonToStruct 一直以来的行为保持一致。
+func fieldJSONKey(fieldType reflect.StructField, opts *decodeOptions) string {
+	tagName := opts.tagName
+	tag := fieldType.Tag.Get(tagName)
+	if tag == "" && tagName == "json" {
+		tag = fieldType.Tag.Get("jsonb")
+	}
+	if tag == "" && tagName == "json" {
+		tag = fieldType.Tag.Get("mapstructure")
+	}
+	if tag == "" {
+		return fieldType.Name
+	}
+	if commaIndex := strings.Index(tag, ","); commaIndex != -1 {
+		tag = tag[:commaIndex]
+	}
+	return tag
 }
 
 func toInt64(value interface{}) (int64, error) {
@@ -182,3 +280,39 @@ func toFloat64(value interface{}) (float64, error) {
 		return 0, errors.New(fmt.Sprintf("jsonUtils toFloat64 err: %T \n", value))
 	}
 }
+
+func toBool(value interface{}, weaklyTyped bool) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if weaklyTyped {
+			switch v {
+			case "true", "1":
+				return true, nil
+			case "false", "0":
+				return false, nil
+			}
+		}
+		return false, errors.New(fmt.Sprintf("jsonUtils toBool err: %T \n", value))
+	default:
+		return false, errors.New(fmt.Sprintf("jsonUtils toBool err: %T \n", value))
+	}
+}
+
+func toStringValue(value interface{}, weaklyTyped bool) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	if weaklyTyped {
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'g', -1, 64), nil
+		case float32:
+			return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	}
+	return "", errors.New(fmt.Sprintf("jsonUtils toString err: %T \n", value))
+}
@@ -0,0 +1,113 @@
+package jsonUtil
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type optionsTestUser struct {
+	Name      string    `json:"name"`
+	Active    bool      `json:"active"`
+	Nickname  string    `json:"nickname"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func TestJsonToStructBasic(t *testing.T) {
+	var u optionsTestUser
+	err := JsonToStruct(`{"name":"Alice","active":true}`, &u)
+	if err != nil {
+		t.Fatalf("JsonToStruct error: %v", err)
+	}
+	if u.Name != "Alice" || !u.Active {
+		t.Errorf("unexpected result: %+v", u)
+	}
+}
+
+func TestJsonToStructWithWeaklyTypedInput(t *testing.T) {
+	var u optionsTestUser
+	err := JsonToStruct(`{"name":"Bob","active":"1","nickname":42}`, &u, WithWeaklyTypedInput())
+	if err != nil {
+		t.Fatalf("JsonToStruct error: %v", err)
+	}
+	if !u.Active {
+		t.Errorf("expected active to coerce from \"1\" to true, got %v", u.Active)
+	}
+	if u.Nickname != "42" {
+		t.Errorf("expected nickname to coerce from number to string, got %q", u.Nickname)
+	}
+}
+
+func TestJsonToStructWithTimeFormat(t *testing.T) {
+	var u optionsTestUser
+	err := JsonToStruct(`{"created_at":"2024-01-02"}`, &u, WithTimeFormat("2006-01-02"))
+	if err != nil {
+		t.Fatalf("JsonToStruct error: %v", err)
+	}
+	want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !u.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v; want %v", u.CreatedAt, want)
+	}
+}
+
+func TestJsonToStructWithDecodeHook(t *testing.T) {
+	type durationHolder struct {
+		Timeout time.Duration `json:"timeout"`
+	}
+
+	hook := func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to == reflect.TypeOf(time.Duration(0)) {
+			if ms, ok := data.(float64); ok {
+				return time.Duration(ms) * time.Millisecond, nil
+			}
+		}
+		return data, nil
+	}
+
+	var h durationHolder
+	err := JsonToStruct(`{"timeout":1500}`, &h, WithDecodeHook(hook))
+	if err != nil {
+		t.Fatalf("JsonToStruct error: %v", err)
+	}
+	if h.Timeout != 1500*time.Millisecond {
+		t.Errorf("Timeout = %v; want 1.5s", h.Timeout)
+	}
+}
+
+func TestJsonToStructWithErrorUnused(t *testing.T) {
+	var u optionsTestUser
+	err := JsonToStruct(`{"name":"Carol","extra_field":1}`, &u, WithErrorUnused())
+	if err == nil {
+		t.Fatal("expected error for unused key, got nil")
+	}
+}
+
+func TestJsonToStructWithMetadata(t *testing.T) {
+	var u optionsTestUser
+	var md Metadata
+	err := JsonToStruct(`{"name":"Dan","extra_field":1}`, &u, WithMetadata(&md))
+	if err != nil {
+		t.Fatalf("JsonToStruct error: %v", err)
+	}
+	if len(md.Unused) != 1 || md.Unused[0] != "extra_field" {
+		t.Errorf("Metadata.Unused = %v; want [extra_field]", md.Unused)
+	}
+	if len(md.Keys) != 1 || md.Keys[0] != "name" {
+		t.Errorf("Metadata.Keys = %v; want [name]", md.Keys)
+	}
+}
+
+func TestJsonToStructWithTagName(t *testing.T) {
+	type mapstructureUser struct {
+		Name string `mapstructure:"full_name"`
+	}
+
+	var u mapstructureUser
+	err := JsonToStruct(`{"full_name":"Erin"}`, &u, WithTagName("mapstructure"))
+	if err != nil {
+		t.Fatalf("JsonToStruct error: %v", err)
+	}
+	if u.Name != "Erin" {
+		t.Errorf("Name = %q; want Erin", u.Name)
+	}
+}
@@ -0,0 +1,83 @@
+package jsonUtil
+
+import "reflect"
+
+// DecodeHookFunc 在把 JSON 值赋给目标字段之前拦截一次，可以返回一个替换值，
+// 比如把 RFC3339 字符串转换成 time.Time、把 base64 字符串转换成 []byte、
+// 把毫秒数转换成 time.Duration。如果返回值的类型能直接赋给目标字段，
+// JsonToStruct 会跳过内置的类型转换逻辑，直接使用这个返回值；否则继续走原来的转换规则。
+type DecodeHookFunc func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error)
+
+// Metadata 记录一次 JsonToStruct 调用中，JSON 里哪些 key 命中了目标结构体的字段、
+// 哪些没有对应字段，嵌套结构体递归解析时遇到的 key 会一并累加进来。
+type Metadata struct {
+	Keys   []string
+	Unused []string
+}
+
+// decodeOptions 收集 JsonToStruct 的所有可选行为。
+type decodeOptions struct {
+	tagName          string
+	timeFormat       string
+	weaklyTypedInput bool
+	decodeHook       DecodeHookFunc
+	errorUnused      bool
+	metadata         *Metadata
+}
+
+// Option 用来配置 JsonToStruct / JsonToStructFromReader 的可选行为。
+type Option func(*decodeOptions)
+
+func newDecodeOptions(opts []Option) *decodeOptions {
+	options := &decodeOptions{tagName: "json"}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithTagName 只按指定的结构体标签（例如 "mapstructure"）确定字段名，
+// 不传时默认依次尝试 "json"、"jsonb"、"mapstructure"。
+// "json" 就是默认值，显式传入 "json" 和不传效果相同，仍会依次回退到 "jsonb"、"mapstructure"。
+func WithTagName(tagName string) Option {
+	return func(o *decodeOptions) {
+		o.tagName = tagName
+	}
+}
+
+// WithTimeFormat 指定解析 time.Time 字段时使用的时间格式，不传时默认用 time.RFC3339。
+func WithTimeFormat(layout string) Option {
+	return func(o *decodeOptions) {
+		o.timeFormat = layout
+	}
+}
+
+// WithWeaklyTypedInput 放宽类型匹配：字符串 "true"/"1"/"false"/"0" 可以转成 bool 字段，
+// 数字和布尔值可以转成 string 字段。
+func WithWeaklyTypedInput() Option {
+	return func(o *decodeOptions) {
+		o.weaklyTypedInput = true
+	}
+}
+
+// WithDecodeHook 注册一个自定义转换钩子，详见 DecodeHookFunc。
+func WithDecodeHook(hook DecodeHookFunc) Option {
+	return func(o *decodeOptions) {
+		o.decodeHook = hook
+	}
+}
+
+// WithErrorUnused 要求 JSON 中不能有任何未被目标结构体消费的 key，否则返回错误，
+// 用于发现拼写错误或者结构体漏定义字段的情况。
+func WithErrorUnused() Option {
+	return func(o *decodeOptions) {
+		o.errorUnused = true
+	}
+}
+
+// WithMetadata 把本次解析过程中命中的 key 和未被使用的 key 记录进 md。
+func WithMetadata(md *Metadata) Option {
+	return func(o *decodeOptions) {
+		o.metadata = md
+	}
+}
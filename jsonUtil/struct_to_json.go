@@ -0,0 +1,292 @@
+package jsonUtil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// StructToJson 把任意值（通常是结构体或结构体指针）序列化为 JSON 字符串，
+// 是 JsonToStruct 的反向操作。
+//
+// 字段名的确定方式和 JsonToStruct 一致，依次读取 "json"、"jsonb"、"mapstructure" 标签，
+// 都没有时才退回字段名本身；标签额外支持 encoding/json 的几个选项：
+//   - ",omitempty" 零值字段不写入
+//   - "-"          整个字段跳过
+//   - ",string"    把数字/布尔值编码成带引号的字符串
+//
+// 另外扩展了一个 ",format=RFC3339" 选项，仅对 time.Time 字段生效，指定输出的时间格式
+// （RFC3339、RFC3339Nano、DateOnly、DateTime 是内置别名，其他值按 Go 时间布局字符串处理）。
+//
+// 支持嵌套结构体、slice、array，以及 key 为 string 或整数类型的 map。
+func StructToJson(v interface{}) (string, error) {
+	node, err := encodeValue(reflect.ValueOf(v), "")
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(node)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// MarshalIndent 和 StructToJson 规则一致，但按 prefix/indent 生成带缩进的 JSON 文本，
+// 用法对应 encoding/json.MarshalIndent。
+func MarshalIndent(v interface{}, prefix, indent string) (string, error) {
+	node, err := encodeValue(reflect.ValueOf(v), "")
+	if err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(node, prefix, indent)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// jsonFieldTag 是解析出来的单个字段的标签信息。
+type jsonFieldTag struct {
+	name      string
+	skip      bool
+	omitempty bool
+	asString  bool
+	format    string
+}
+
+// parseJSONFieldTag 依次从 json、jsonb、mapstructure 标签中取字段名和选项，
+// 规则与 StructToJson 的文档说明保持一致。
+func parseJSONFieldTag(field reflect.StructField) jsonFieldTag {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		tag = field.Tag.Get("jsonb")
+	}
+	if tag == "" {
+		tag = field.Tag.Get("mapstructure")
+	}
+
+	if tag == "-" {
+		return jsonFieldTag{skip: true}
+	}
+
+	info := jsonFieldTag{name: field.Name}
+	if tag == "" {
+		return info
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		info.name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "omitempty":
+			info.omitempty = true
+		case opt == "string":
+			info.asString = true
+		case strings.HasPrefix(opt, "format="):
+			info.format = strings.TrimPrefix(opt, "format=")
+		}
+	}
+	return info
+}
+
+// encodeValue 把 rv 转换成可以直接交给 encoding/json 编码的值（nil、bool、数字、string、
+// []interface{}、map[string]interface{}，结构体则是保留字段声明顺序的 json.RawMessage），
+// format 是从父级字段标签透传下来的时间格式（如果有）。
+func encodeValue(rv reflect.Value, format string) (interface{}, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return encodeValue(rv.Elem(), format)
+	case reflect.Struct:
+		if rv.Type() == timeType {
+			return rv.Interface().(time.Time).Format(resolveTimeLayout(format)), nil
+		}
+		return encodeStruct(rv)
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return encodeSequence(rv, format)
+	case reflect.Array:
+		return encodeSequence(rv, format)
+	case reflect.Map:
+		return encodeMap(rv, format)
+	default:
+		return rv.Interface(), nil
+	}
+}
+
+// resolveTimeLayout 把 ",format=xxx" 里的 xxx 解析成 time.Format 能识别的布局字符串，
+// 内置几个常用别名，其余原样当作自定义 Go 时间布局传下去。
+func resolveTimeLayout(format string) string {
+	switch format {
+	case "", "RFC3339":
+		return time.RFC3339
+	case "RFC3339Nano":
+		return time.RFC3339Nano
+	case "DateOnly":
+		return "2006-01-02"
+	case "DateTime":
+		return "2006-01-02 15:04:05"
+	default:
+		return format
+	}
+}
+
+// encodeStruct 把结构体编码成一个 json.RawMessage，字段按声明顺序依次写入，
+// 而不是先收集进 map[string]interface{} 再交给 json.Marshal（那样会被按 key 字母序重排）。
+// 这样 StructToJson/MarshalIndent 的字段顺序才能和 encoding/json.Marshal 对同一个结构体的输出
+// 保持逐字节一致，而不仅仅是语义等价。
+func encodeStruct(rv reflect.Value) (interface{}, error) {
+	t := rv.Type()
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	wroteField := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // 未导出字段
+		}
+
+		info := parseJSONFieldTag(field)
+		if info.skip {
+			continue
+		}
+
+		fieldValue := rv.Field(i)
+		if info.omitempty && isEmptyValue(fieldValue) {
+			continue
+		}
+
+		encoded, err := encodeValue(fieldValue, info.format)
+		if err != nil {
+			return nil, err
+		}
+		if info.asString {
+			encoded = stringify(encoded)
+		}
+
+		keyJSON, err := json.Marshal(info.name)
+		if err != nil {
+			return nil, err
+		}
+		valJSON, err := json.Marshal(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		if wroteField {
+			buf.WriteByte(',')
+		}
+		wroteField = true
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(valJSON)
+	}
+	buf.WriteByte('}')
+	return json.RawMessage(buf.Bytes()), nil
+}
+
+func encodeSequence(rv reflect.Value, format string) (interface{}, error) {
+	out := make([]interface{}, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		v, err := encodeValue(rv.Index(i), format)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func encodeMap(rv reflect.Value, format string) (interface{}, error) {
+	if rv.IsNil() {
+		return nil, nil
+	}
+	out := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := mapKeyToString(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		val, err := encodeValue(iter.Value(), format)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = val
+	}
+	return out, nil
+}
+
+func mapKeyToString(rv reflect.Value) (string, error) {
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("jsonUtil: unsupported map key type %s", rv.Type())
+	}
+}
+
+// isEmptyValue 判断字段是否是其类型的零值，用来实现 ",omitempty"。
+// 和 encoding/json 的行为保持一致：struct 永远不被当成空值（即使是零值 time.Time），
+// 所以这里没有对 time.Time 做特殊处理。
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// stringify 把数字/布尔这类原始值转换成它们的十进制/字面量字符串形式，用于实现 ",string"。
+func stringify(v interface{}) interface{} {
+	switch val := v.(type) {
+	case bool:
+		return strconv.FormatBool(val)
+	case string:
+		return val
+	case nil:
+		return v
+	default:
+		rv := reflect.ValueOf(val)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(rv.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(rv.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+		default:
+			return v
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package jsonUtil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type jsonAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type jsonUser struct {
+	Name      string         `json:"name"`
+	Age       int            `json:"age,string"`
+	Address   jsonAddress    `json:"address"`
+	Tags      []string       `json:"tags,omitempty"`
+	Scores    map[string]int `json:"scores"`
+	Nickname  *string        `json:"nickname,omitempty"`
+	Password  string         `json:"-"`
+	CreatedAt time.Time      `json:"created_at,format=DateOnly"`
+}
+
+func TestStructToJsonRoundTrip(t *testing.T) {
+	nickname := "ace"
+	user := jsonUser{
+		Name:      "Alice",
+		Age:       30,
+		Address:   jsonAddress{City: "Chongqing"},
+		Scores:    map[string]int{"math": 90},
+		Nickname:  &nickname,
+		Password:  "secret",
+		CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	got, err := StructToJson(user)
+	if err != nil {
+		t.Fatalf("StructToJson error: %v", err)
+	}
+
+	var gotMap map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &gotMap); err != nil {
+		t.Fatalf("result is not valid JSON: %v, raw: %s", err, got)
+	}
+
+	if _, ok := gotMap["Password"]; ok {
+		t.Errorf("field tagged \"-\" should not be present, got: %s", got)
+	}
+	if _, ok := gotMap["zip"]; ok {
+		t.Errorf("omitempty field should be omitted, got: %s", got)
+	}
+	if gotMap["age"] != "30" {
+		t.Errorf("age should be encoded as string \"30\", got: %v", gotMap["age"])
+	}
+	if gotMap["created_at"] != "2024-01-02" {
+		t.Errorf("created_at should use the DateOnly format, got: %v", gotMap["created_at"])
+	}
+
+	address, ok := gotMap["address"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("address should be encoded as an object, got: %T", gotMap["address"])
+	}
+	if address["city"] != "Chongqing" {
+		t.Errorf("nested struct field mismatch, got: %v", address["city"])
+	}
+	if _, ok := address["zip"]; ok {
+		t.Errorf("omitempty field should be omitted, got: %s", got)
+	}
+
+	scores, ok := gotMap["scores"].(map[string]interface{})
+	if !ok || scores["math"] != float64(90) {
+		t.Errorf("map field mismatch, got: %v", gotMap["scores"])
+	}
+}
+
+type plainUser struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+// TestStructToJsonMatchesEncodingJson 验证没有用到扩展标签时，输出和标准库在语义上保持一致。
+func TestStructToJsonMatchesEncodingJson(t *testing.T) {
+	u := plainUser{Name: "Bob", Age: 25}
+
+	got, err := StructToJson(u)
+	if err != nil {
+		t.Fatalf("StructToJson error: %v", err)
+	}
+
+	want, err := json.Marshal(u)
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+
+	var gotMap, wantMap map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &gotMap); err != nil {
+		t.Fatalf("result is not valid JSON: %v", err)
+	}
+	if err := json.Unmarshal(want, &wantMap); err != nil {
+		t.Fatalf("want is not valid JSON: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotMap, wantMap) {
+		t.Errorf("StructToJson(%v) = %s; want semantically equal to %s", u, got, want)
+	}
+}
+
+// TestStructToJsonOmitemptyKeepsZeroTime 验证 ",omitempty" 对零值 time.Time 字段的行为
+// 和 encoding/json 保持一致：struct 从不被 omitempty 省略，哪怕它是零值。
+func TestStructToJsonOmitemptyKeepsZeroTime(t *testing.T) {
+	type withTime struct {
+		When time.Time `json:"when,omitempty"`
+		N    int       `json:"n"`
+	}
+	v := withTime{N: 5}
+
+	got, err := StructToJson(v)
+	if err != nil {
+		t.Fatalf("StructToJson error: %v", err)
+	}
+
+	want, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+
+	var gotMap, wantMap map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &gotMap); err != nil {
+		t.Fatalf("result is not valid JSON: %v, raw: %s", err, got)
+	}
+	if err := json.Unmarshal(want, &wantMap); err != nil {
+		t.Fatalf("want is not valid JSON: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotMap, wantMap) {
+		t.Errorf("StructToJson(%v) = %s; want semantically equal to stdlib output %s", v, got, want)
+	}
+}
+
+// TestStructToJsonPreservesFieldOrder 验证输出字段顺序和 encoding/json 逐字节一致，
+// 而不只是语义等价：字段名本身不按字母序排列时最能暴露 map 重排的问题。
+func TestStructToJsonPreservesFieldOrder(t *testing.T) {
+	type unordered struct {
+		Zebra string `json:"zebra"`
+		Apple int    `json:"apple"`
+		Mango bool   `json:"mango"`
+	}
+	v := unordered{Zebra: "z", Apple: 1, Mango: true}
+
+	got, err := StructToJson(v)
+	if err != nil {
+		t.Fatalf("StructToJson error: %v", err)
+	}
+
+	want, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("StructToJson(%v) = %s; want byte-identical to %s", v, got, want)
+	}
+}
+
+func TestMarshalIndent(t *testing.T) {
+	u := plainUser{Name: "Carol", Age: 40}
+
+	got, err := MarshalIndent(u, "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent error: %v", err)
+	}
+
+	want, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		t.Fatalf("json.MarshalIndent error: %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("MarshalIndent = %s; want %s", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+package mapUtil
+
+import "reflect"
+
+// Diff 比较 a 和 b（struct、指向 struct 的指针，或 map[string]interface{}），
+// 返回 b 相对 a 发生变化的字段：新增的字段、值不同的字段，以及（递归比较后）
+// 内部有变化的嵌套字段，value 取的是 b 里的新值。a 中有而 b 中没有的字段不会出现在结果里。
+// a 或 b 为 nil、或者不是上述几种类型时返回空 map；a 和 b 是不同类型的 struct 时仍按字段名逐个比较。
+// 典型用法是只把变化过的字段同步写回 Redis hash 或数据库，而不是整条记录全量覆盖。
+func Diff(a, b interface{}) map[string]interface{} {
+	aMap, errA := toReadMap(a)
+	bMap, errB := toReadMap(b)
+	if errA != nil || errB != nil {
+		return map[string]interface{}{}
+	}
+	return diffMaps(aMap, bMap)
+}
+
+func diffMaps(a, b map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, bv := range b {
+		av, exists := a[k]
+		if !exists {
+			out[k] = bv
+			continue
+		}
+
+		aMapVal, aIsMap := av.(map[string]interface{})
+		bMapVal, bIsMap := bv.(map[string]interface{})
+		if aIsMap && bIsMap {
+			if nested := diffMaps(aMapVal, bMapVal); len(nested) > 0 {
+				out[k] = nested
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(av, bv) {
+			out[k] = bv
+		}
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+// Package mapUtil 提供 struct 与 map[string]interface{} 之间的互相转换，
+// 以及基于这种转换的深度合并（Merge）和字段级差异（Diff）工具。
+//
+// 和 jsonUtil.JsonToStruct 不同，这里不经过 JSON 字符串中转，直接用反射在
+// struct 和 map 之间搬运值，避免了一次多余的序列化/反序列化，也因此能保留
+// time.Time、json.Number 这类 JSON 文本里看不出原始类型的值。
+package mapUtil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// StructToMap 把 v（struct 或指向 struct 的指针）转换成 map[string]interface{}。
+// 字段名的确定方式和 jsonUtil.JsonToStruct 一致：依次读取 "json"、"jsonb"、"mapstructure" 标签，
+// 都没有时退回字段名本身；标签为 "-" 的字段会被跳过。
+func StructToMap(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("mapUtil: StructToMap received a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mapUtil: StructToMap expects a struct, got %s", rv.Kind())
+	}
+	return structToMapValue(rv), nil
+}
+
+// MapToStruct 把 m 的值填充进 result 指向的 struct，字段名解析规则和 StructToMap 相同。
+func MapToStruct(m map[string]interface{}, result interface{}) error {
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("mapUtil: MapToStruct result must be a non-nil pointer to a struct")
+	}
+	elem := rv.Elem()
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("mapUtil: MapToStruct result must point to a struct, got %s", elem.Kind())
+	}
+	return mapToStructValue(m, elem)
+}
+
+func structToMapValue(rv reflect.Value) map[string]interface{} {
+	t := rv.Type()
+	out := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // 未导出字段
+		}
+		name, skip := fieldName(field)
+		if skip {
+			continue
+		}
+		out[name] = valueToMapEntry(rv.Field(i))
+	}
+	return out
+}
+
+func valueToMapEntry(fv reflect.Value) interface{} {
+	switch fv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if fv.IsNil() {
+			return nil
+		}
+		return valueToMapEntry(fv.Elem())
+	case reflect.Struct:
+		if fv.Type() == timeType {
+			return fv.Interface() // 保留 time.Time 本身的类型，不拆成字段
+		}
+		return structToMapValue(fv)
+	case reflect.Slice, reflect.Array:
+		if fv.Kind() == reflect.Slice && fv.IsNil() {
+			return nil
+		}
+		out := make([]interface{}, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			out[i] = valueToMapEntry(fv.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if fv.IsNil() {
+			return nil
+		}
+		out := make(map[string]interface{}, fv.Len())
+		iter := fv.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = valueToMapEntry(iter.Value())
+		}
+		return out
+	default:
+		return fv.Interface()
+	}
+}
+
+func mapToStructValue(m map[string]interface{}, rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, skip := fieldName(field)
+		if skip {
+			continue
+		}
+		value, ok := m[name]
+		if !ok {
+			continue
+		}
+		if err := setFieldValue(rv.Field(i), value); err != nil {
+			return fmt.Errorf("mapUtil: field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func setFieldValue(fv reflect.Value, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return setFieldValue(fv.Elem(), value)
+	}
+
+	valueRv := reflect.ValueOf(value)
+	if valueRv.Type().AssignableTo(fv.Type()) {
+		fv.Set(valueRv)
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Struct:
+		subMap, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected map[string]interface{}, got %T", value)
+		}
+		return mapToStructValue(subMap, fv)
+	case reflect.Slice:
+		subSlice, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected []interface{}, got %T", value)
+		}
+		out := reflect.MakeSlice(fv.Type(), len(subSlice), len(subSlice))
+		for i, elem := range subSlice {
+			if err := setFieldValue(out.Index(i), elem); err != nil {
+				return err
+			}
+		}
+		fv.Set(out)
+		return nil
+	case reflect.Map:
+		subMap, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected map[string]interface{}, got %T", value)
+		}
+		out := reflect.MakeMapWithSize(fv.Type(), len(subMap))
+		for k, v := range subMap {
+			elemValue := reflect.New(fv.Type().Elem()).Elem()
+			if err := setFieldValue(elemValue, v); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(fv.Type().Key()), elemValue)
+		}
+		fv.Set(out)
+		return nil
+	default:
+		if valueRv.Type().ConvertibleTo(fv.Type()) {
+			fv.Set(valueRv.Convert(fv.Type()))
+			return nil
+		}
+		return fmt.Errorf("cannot assign %T to %s", value, fv.Type())
+	}
+}
+
+// fieldName 依次尝试 "json"、"jsonb"、"mapstructure" 标签确定字段名，标签为 "-" 时 skip 为 true。
+func fieldName(field reflect.StructField) (name string, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		tag = field.Tag.Get("jsonb")
+	}
+	if tag == "" {
+		tag = field.Tag.Get("mapstructure")
+	}
+	if tag == "-" {
+		return "", true
+	}
+	if tag == "" {
+		return field.Name, false
+	}
+	if idx := strings.Index(tag, ","); idx != -1 {
+		tag = tag[:idx]
+	}
+	if tag == "" {
+		return field.Name, false
+	}
+	return tag, false
+}
@@ -0,0 +1,118 @@
+package mapUtil
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mapUtilAddress struct {
+	City string `json:"city"`
+}
+
+type mapUtilUser struct {
+	Name      string         `json:"name"`
+	Age       int            `json:"age"`
+	Address   mapUtilAddress `json:"address"`
+	Tags      []string       `json:"tags"`
+	CreatedAt time.Time      `json:"created_at"`
+	Secret    string         `json:"-"`
+}
+
+func TestStructToMapAndBack(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := mapUtilUser{
+		Name:      "Alice",
+		Age:       30,
+		Address:   mapUtilAddress{City: "Chongqing"},
+		Tags:      []string{"a", "b"},
+		CreatedAt: createdAt,
+		Secret:    "hide-me",
+	}
+
+	m, err := StructToMap(user)
+	if err != nil {
+		t.Fatalf("StructToMap error: %v", err)
+	}
+	if _, ok := m["Secret"]; ok {
+		t.Errorf("field tagged \"-\" should not appear in map, got: %v", m)
+	}
+	if _, ok := m["created_at"].(time.Time); !ok {
+		t.Errorf("created_at should keep its time.Time type, got %T", m["created_at"])
+	}
+
+	var back mapUtilUser
+	if err := MapToStruct(m, &back); err != nil {
+		t.Fatalf("MapToStruct error: %v", err)
+	}
+	back.Secret = user.Secret // 标签为 "-"，StructToMap/MapToStruct 都不会处理这个字段
+	if !reflect.DeepEqual(back, user) {
+		t.Errorf("round trip mismatch: got %+v, want %+v", back, user)
+	}
+}
+
+func TestMergeDefaultsToNonZeroOverwrite(t *testing.T) {
+	dst := mapUtilUser{Name: "Alice", Age: 30, Tags: []string{"a"}}
+	src := mapUtilUser{Name: "", Age: 31, Tags: []string{"b"}}
+
+	if err := Merge(&dst, src); err != nil {
+		t.Fatalf("Merge error: %v", err)
+	}
+	if dst.Name != "Alice" {
+		t.Errorf("zero-value Name from src should not overwrite dst, got %q", dst.Name)
+	}
+	if dst.Age != 31 {
+		t.Errorf("Age should be overwritten to 31, got %d", dst.Age)
+	}
+	if len(dst.Tags) != 1 || dst.Tags[0] != "b" {
+		t.Errorf("Tags should be replaced by src, got %v", dst.Tags)
+	}
+}
+
+func TestMergeWithOverwriteZero(t *testing.T) {
+	dst := mapUtilUser{Name: "Alice", Age: 30}
+	src := mapUtilUser{Name: "", Age: 31}
+
+	if err := Merge(&dst, src, WithOverwriteZero()); err != nil {
+		t.Fatalf("Merge error: %v", err)
+	}
+	if dst.Name != "" {
+		t.Errorf("WithOverwriteZero should let the zero value win, got %q", dst.Name)
+	}
+}
+
+func TestMergeWithAppendSlices(t *testing.T) {
+	dst := mapUtilUser{Tags: []string{"a"}}
+	src := mapUtilUser{Tags: []string{"b", "c"}}
+
+	if err := Merge(&dst, src, WithAppendSlices()); err != nil {
+		t.Fatalf("Merge error: %v", err)
+	}
+	want := []string{"a", "b", "c"}
+	if len(dst.Tags) != len(want) {
+		t.Fatalf("Tags = %v; want %v", dst.Tags, want)
+	}
+	for i := range want {
+		if dst.Tags[i] != want[i] {
+			t.Errorf("Tags[%d] = %q; want %q", i, dst.Tags[i], want[i])
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := mapUtilUser{Name: "Alice", Age: 30, Address: mapUtilAddress{City: "Chongqing"}}
+	b := mapUtilUser{Name: "Alice", Age: 31, Address: mapUtilAddress{City: "Beijing"}}
+
+	changes := Diff(a, b)
+
+	if _, ok := changes["name"]; ok {
+		t.Errorf("unchanged field \"name\" should not be in the diff, got: %v", changes)
+	}
+	if changes["age"] != 31 {
+		t.Errorf("changes[\"age\"] = %v; want 31", changes["age"])
+	}
+	address, ok := changes["address"].(map[string]interface{})
+	if !ok || address["city"] != "Beijing" {
+		t.Errorf("changes[\"address\"] = %v; want nested diff with city=Beijing", changes["address"])
+	}
+}
@@ -0,0 +1,163 @@
+package mapUtil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// mergeOptions 收集 Merge 的可选行为。
+type mergeOptions struct {
+	overwriteZero bool
+	appendSlices  bool
+}
+
+// MergeOption 用来配置 Merge 的可选行为。
+type MergeOption func(*mergeOptions)
+
+// WithOverwriteZero 默认情况下 src 里的零值不会覆盖 dst 已有的值（避免"没传的字段"误把已有数据清空）；
+// 传入这个选项后零值也会覆盖。
+func WithOverwriteZero() MergeOption {
+	return func(o *mergeOptions) {
+		o.overwriteZero = true
+	}
+}
+
+// WithAppendSlices 默认情况下同名的 slice 字段由 src 直接替换 dst；
+// 传入这个选项后改成把 dst 和 src 的 slice 依次拼接起来。
+func WithAppendSlices() MergeOption {
+	return func(o *mergeOptions) {
+		o.appendSlices = true
+	}
+}
+
+// Merge 把 src 的字段深度合并进 dst，dst 必须是指向 struct 或 map[string]interface{} 的指针，
+// src 可以是 struct、指向 struct 的指针，或者 map[string]interface{}。
+// 这在把一份增量数据（比如从 Redis hash 读出来的字段）合并进一个已有记录时很常用。
+func Merge(dst, src interface{}, opts ...MergeOption) error {
+	options := &mergeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	dstMap, err := toMutableMap(dst)
+	if err != nil {
+		return err
+	}
+	srcMap, err := toReadMap(src)
+	if err != nil {
+		return err
+	}
+
+	merged := mergeMaps(dstMap, srcMap, options)
+
+	return writeBack(dst, merged)
+}
+
+func mergeMaps(dst, src map[string]interface{}, opts *mergeOptions) map[string]interface{} {
+	out := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, sv := range src {
+		dv, exists := out[k]
+		if !exists {
+			out[k] = sv
+			continue
+		}
+		out[k] = mergeValue(dv, sv, opts)
+	}
+	return out
+}
+
+func mergeValue(dv, sv interface{}, opts *mergeOptions) interface{} {
+	if !opts.overwriteZero && isZeroValue(sv) {
+		return dv
+	}
+
+	if dvMap, ok := dv.(map[string]interface{}); ok {
+		if svMap, ok := sv.(map[string]interface{}); ok {
+			return mergeMaps(dvMap, svMap, opts)
+		}
+	}
+
+	if opts.appendSlices {
+		if dvSlice, ok := dv.([]interface{}); ok {
+			if svSlice, ok := sv.([]interface{}); ok {
+				appended := make([]interface{}, 0, len(dvSlice)+len(svSlice))
+				appended = append(appended, dvSlice...)
+				appended = append(appended, svSlice...)
+				return appended
+			}
+		}
+	}
+
+	return sv
+}
+
+func isZeroValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.IsZero()
+}
+
+// toReadMap 把 v（struct、指向 struct 的指针，或 map[string]interface{}）转换成只读的 map 视图。
+func toReadMap(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return nil, errors.New("mapUtil: value is nil")
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+	if mp, ok := v.(*map[string]interface{}); ok {
+		if mp == nil || *mp == nil {
+			return map[string]interface{}{}, nil
+		}
+		return *mp, nil
+	}
+	return StructToMap(v)
+}
+
+// toMutableMap 读出 dst 当前指向的内容，dst 必须是指向 struct 或 map[string]interface{} 的指针。
+func toMutableMap(dst interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, errors.New("mapUtil: dst must be a non-nil pointer to a struct or map[string]interface{}")
+	}
+
+	elem := rv.Elem()
+	switch elem.Kind() {
+	case reflect.Map:
+		if elem.Type() != reflect.TypeOf(map[string]interface{}{}) {
+			return nil, fmt.Errorf("mapUtil: dst map must be map[string]interface{}, got %s", elem.Type())
+		}
+		out := make(map[string]interface{}, elem.Len())
+		if !elem.IsNil() {
+			iter := elem.MapRange()
+			for iter.Next() {
+				out[iter.Key().String()] = iter.Value().Interface()
+			}
+		}
+		return out, nil
+	case reflect.Struct:
+		return structToMapValue(elem), nil
+	default:
+		return nil, fmt.Errorf("mapUtil: dst must point to a struct or map[string]interface{}, got %s", elem.Kind())
+	}
+}
+
+// writeBack 把合并后的结果写回 dst（指向 struct 或 map[string]interface{} 的指针）。
+func writeBack(dst interface{}, merged map[string]interface{}) error {
+	elem := reflect.ValueOf(dst).Elem()
+	if elem.Kind() == reflect.Map {
+		out := reflect.MakeMapWithSize(elem.Type(), len(merged))
+		for k, v := range merged {
+			out.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(v))
+		}
+		elem.Set(out)
+		return nil
+	}
+	return mapToStructValue(merged, elem)
+}